@@ -0,0 +1,199 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+// CustomFieldSchema returns the `custom` block shared by every resource that
+// supports commercetools custom fields (a Type plus a FieldContainer of
+// values). Field values are stored JSON-encoded so non-string types
+// (numbers, booleans, references, LocalizedString, ...) survive the
+// round-trip through Terraform state without the schema needing to know
+// their underlying type.
+func CustomFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The custom fields for this resource, as defined by a commercetools Type.\n" +
+			"See also the [Custom Fields API Documentation](https://docs.commercetools.com/api/projects/custom-fields)",
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type_id": {
+					Description:  "ID of the Type that defines the custom fields. Either `type_id` or `type_key` is required",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"custom.0.type_id", "custom.0.type_key"},
+				},
+				"type_key": {
+					Description:  "Key of the Type that defines the custom fields. Either `type_id` or `type_key` is required",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"custom.0.type_id", "custom.0.type_key"},
+				},
+				"fields": {
+					Description: "Map of field name to JSON-encoded field value",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// expandCustomFieldsDraft reads a `custom` block from resource data into a
+// CustomFieldsDraft, returning nil when the block is absent.
+func expandCustomFieldsDraft(d *schema.ResourceData) (*platform.CustomFieldsDraft, error) {
+	block, ok := customBlock(d.Get("custom"))
+	if !ok {
+		return nil, nil
+	}
+
+	fields, err := encodeCustomFieldsMap(block["fields"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &platform.CustomFieldsDraft{
+		Type:   *customTypeResourceIdentifier(block),
+		Fields: fields,
+	}, nil
+}
+
+// customTypeResourceIdentifier builds the TypeResourceIdentifier for a
+// `custom` block, preferring `type_id` and falling back to `type_key`
+// (the schema's ExactlyOneOf guarantees exactly one of them is set).
+func customTypeResourceIdentifier(block map[string]interface{}) *platform.TypeResourceIdentifier {
+	if id, _ := block["type_id"].(string); id != "" {
+		return &platform.TypeResourceIdentifier{ID: &id}
+	}
+	if key, _ := block["type_key"].(string); key != "" {
+		return &platform.TypeResourceIdentifier{Key: &key}
+	}
+	return nil
+}
+
+// customTypeIdentity returns a string uniquely identifying the Type
+// referenced by a `custom` block (by id or key), for change detection.
+func customTypeIdentity(block map[string]interface{}) string {
+	if id, _ := block["type_id"].(string); id != "" {
+		return "id:" + id
+	}
+	if key, _ := block["type_key"].(string); key != "" {
+		return "key:" + key
+	}
+	return ""
+}
+
+// flattenCustomFields converts the Custom fields returned by the API back
+// into the `custom` block shape, JSON-encoding each value so the diff stays
+// stable.
+func flattenCustomFields(custom *platform.CustomFields) ([]map[string]interface{}, error) {
+	if custom == nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	fields := map[string]interface{}{}
+	for key, value := range custom.Fields {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode custom field %q as JSON: %w", key, err)
+		}
+		fields[key] = string(encoded)
+	}
+
+	return []map[string]interface{}{
+		{
+			"type_id": custom.Type.ID,
+			"fields":  fields,
+		},
+	}, nil
+}
+
+// customFieldsUpdateActions diffs the old and new `custom` block values and
+// returns the actions needed to reconcile them: a single "set custom type"
+// action when the type itself changed, or one "set custom field" action per
+// changed/removed field otherwise. setType and setField build the
+// resource-specific update action (e.g. CustomerGroupSetCustomTypeAction),
+// keeping this helper reusable across resources.
+func customFieldsUpdateActions[T any](
+	d *schema.ResourceData,
+	setType func(typeRef *platform.TypeResourceIdentifier, fields *platform.FieldContainer) T,
+	setField func(name string, value interface{}) T,
+) ([]T, error) {
+	if !d.HasChange("custom") {
+		return nil, nil
+	}
+
+	old, new := d.GetChange("custom")
+	oldBlock, _ := customBlock(old)
+	newBlock, hasNew := customBlock(new)
+
+	if customTypeIdentity(oldBlock) != customTypeIdentity(newBlock) {
+		if !hasNew {
+			// The `custom` block was removed entirely: explicitly unset the
+			// custom type remotely instead of leaving it in place, or Read
+			// would keep re-populating `custom` and the plan would never converge.
+			return []T{setType(nil, nil)}, nil
+		}
+		fields, err := encodeCustomFieldsMap(newBlock["fields"].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		return []T{setType(customTypeResourceIdentifier(newBlock), fields)}, nil
+	}
+
+	oldFields, _ := oldBlock["fields"].(map[string]interface{})
+	newFields, _ := newBlock["fields"].(map[string]interface{})
+
+	actions := []T{}
+	for name, value := range newFields {
+		if oldFields[name] != value {
+			decoded, err := decodeCustomFieldValue(name, value.(string))
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, setField(name, decoded))
+		}
+	}
+	for name := range oldFields {
+		if _, ok := newFields[name]; !ok {
+			actions = append(actions, setField(name, nil))
+		}
+	}
+
+	return actions, nil
+}
+
+func customBlock(value interface{}) (map[string]interface{}, bool) {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return map[string]interface{}{}, false
+	}
+	return list[0].(map[string]interface{}), true
+}
+
+func encodeCustomFieldsMap(raw map[string]interface{}) (*platform.FieldContainer, error) {
+	fields := platform.FieldContainer{}
+	for key, value := range raw {
+		decoded, err := decodeCustomFieldValue(key, value.(string))
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = decoded
+	}
+	return &fields, nil
+}
+
+func decodeCustomFieldValue(name string, value string) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode custom field %q as JSON: %w", name, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,86 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+func dataSourceCustomerGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Look up a customer group by its `key` or `name`, for example to reference a customer " +
+			"group that was created outside of Terraform in a `commercetools_customer_group_membership` " +
+			"resource or in a pricing configuration.\n\n" +
+			"See also the [Customer Group API Documentation](https://docs.commercetools.com/api/projects/customerGroups)",
+		ReadContext: dataSourceCustomerGroupRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Description:  "User-specific unique identifier for the customer group",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"key", "name"},
+			},
+			"name": {
+				Description:  "Unique within the project",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"key", "name"},
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCustomerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(m)
+
+	var customerGroup *platform.CustomerGroup
+
+	if key, ok := d.GetOk("key"); ok {
+		log.Printf("[DEBUG] Looking up customer group by key: %s", key)
+
+		result, err := client.CustomerGroups().WithKey(key.(string)).Get().Execute(ctx)
+		if err != nil {
+			if ctErr, ok := err.(platform.ErrorResponse); ok {
+				if ctErr.StatusCode == 404 {
+					return diag.Errorf("no customer group found with key %q", key)
+				}
+			}
+			return diag.FromErr(err)
+		}
+		customerGroup = result
+	} else {
+		name := d.Get("name").(string)
+		log.Printf("[DEBUG] Looking up customer group by name: %s", name)
+
+		result, err := client.CustomerGroups().Get().WithQueryParams(platform.ByProjectKeyCustomerGroupsGetMethodParams{
+			Where: []string{fmt.Sprintf(`name="%s"`, name)},
+		}).Execute(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(result.Results) {
+		case 0:
+			return diag.Errorf("no customer group found with name %q", name)
+		case 1:
+			customerGroup = &result.Results[0]
+		default:
+			return diag.Errorf("more than one customer group found with name %q", name)
+		}
+	}
+
+	d.SetId(customerGroup.ID)
+	d.Set("version", customerGroup.Version)
+	d.Set("name", customerGroup.Name)
+	d.Set("key", customerGroup.Key)
+
+	return nil
+}
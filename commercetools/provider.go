@@ -0,0 +1,21 @@
+package commercetools
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the schema.Provider for the commercetools Terraform
+// provider. Only the resources and data sources touched by this series are
+// listed here; the rest of the provider's schema and configuration lives
+// alongside the remainder of the `resource_*.go`/`data_source_*.go` files.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"commercetools_customer_group":            resourceCustomerGroup(),
+			"commercetools_customer_group_membership": resourceCustomerGroupMembership(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"commercetools_customer_group": dataSourceCustomerGroup(),
+		},
+	}
+}
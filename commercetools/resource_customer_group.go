@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
@@ -15,10 +16,10 @@ func resourceCustomerGroup() *schema.Resource {
 		Description: "A Customer can be a member of a customer group (for example reseller, gold member). " +
 			"Special prices can be assigned to specific products based on a customer group.\n\n" +
 			"See also the [Custome Group API Documentation](https://docs.commercetools.com/api/projects/customerGroups)",
-		Create: resourceCustomerGroupCreate,
-		Read:   resourceCustomerGroupRead,
-		Update: resourceCustomerGroupUpdate,
-		Delete: resourceCustomerGroupDelete,
+		CreateContext: resourceCustomerGroupCreate,
+		ReadContext:   resourceCustomerGroupRead,
+		UpdateContext: resourceCustomerGroupUpdate,
+		DeleteContext: resourceCustomerGroupDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -37,23 +38,30 @@ func resourceCustomerGroup() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"custom": CustomFieldSchema(),
 		},
 	}
 }
 
-func resourceCustomerGroupCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCustomerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := getClient(m)
 	var customerGroup *platform.CustomerGroup
 
+	custom, err := expandCustomFieldsDraft(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	draft := platform.CustomerGroupDraft{
 		GroupName: d.Get("name").(string),
 		Key:       stringRef(d.Get("key")),
+		Custom:    custom,
 	}
 
-	errorResponse := resource.Retry(1*time.Minute, func() *resource.RetryError {
+	errorResponse := resource.RetryContext(ctx, 1*time.Minute, func() *resource.RetryError {
 		var err error
 
-		customerGroup, err = client.CustomerGroups().Post(draft).Execute(context.Background())
+		customerGroup, err = client.CustomerGroups().Post(draft).Execute(ctx)
 
 		if err != nil {
 			return handleCommercetoolsError(err)
@@ -62,25 +70,25 @@ func resourceCustomerGroupCreate(d *schema.ResourceData, m interface{}) error {
 	})
 
 	if errorResponse != nil {
-		return errorResponse
+		return diag.FromErr(errorResponse)
 	}
 
 	if customerGroup == nil {
-		log.Fatal("No customer group")
+		return diag.Errorf("no customer group was created")
 	}
 
 	d.SetId(customerGroup.ID)
 	d.Set("version", customerGroup.Version)
 
-	return resourceCustomerGroupRead(d, m)
+	return resourceCustomerGroupRead(ctx, d, m)
 }
 
-func resourceCustomerGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceCustomerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] Reading customer group from commercetools, with customer group id: %s", d.Id())
 
 	client := getClient(m)
 
-	customerGroup, err := client.CustomerGroups().WithId(d.Id()).Get().Execute(context.Background())
+	customerGroup, err := client.CustomerGroups().WithId(d.Id()).Get().Execute(ctx)
 
 	if err != nil {
 		if ctErr, ok := err.(platform.ErrorResponse); ok {
@@ -89,7 +97,7 @@ func resourceCustomerGroupRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if customerGroup == nil {
@@ -99,19 +107,25 @@ func resourceCustomerGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Print("[DEBUG] Found following customer group:")
 		log.Print(stringFormatObject(customerGroup))
 
+		custom, err := flattenCustomFields(customerGroup.Custom)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		d.Set("version", customerGroup.Version)
 		d.Set("name", customerGroup.Name)
 		d.Set("key", customerGroup.Key)
+		d.Set("custom", custom)
 	}
 
 	return nil
 }
 
-func resourceCustomerGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCustomerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := getClient(m)
-	customerGroup, err := client.CustomerGroups().WithId(d.Id()).Get().Execute(context.Background())
+	customerGroup, err := client.CustomerGroups().WithId(d.Id()).Get().Execute(ctx)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	input := platform.CustomerGroupUpdate{
@@ -133,30 +147,43 @@ func resourceCustomerGroupUpdate(d *schema.ResourceData, m interface{}) error {
 			&platform.CustomerGroupSetKeyAction{Key: &newKey})
 	}
 
+	customActions, err := customFieldsUpdateActions(
+		d,
+		func(typeRef *platform.TypeResourceIdentifier, fields *platform.FieldContainer) platform.CustomerGroupUpdateAction {
+			return &platform.CustomerGroupSetCustomTypeAction{Type: typeRef, Fields: fields}
+		},
+		func(name string, value interface{}) platform.CustomerGroupUpdateAction {
+			return &platform.CustomerGroupSetCustomFieldAction{Name: name, Value: value}
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	input.Actions = append(input.Actions, customActions...)
+
 	log.Printf(
 		"[DEBUG] Will perform update operation with the following actions:\n%s",
 		stringFormatActions(input.Actions))
 
-	_, err = client.CustomerGroups().WithId(d.Id()).Post(input).Execute(context.Background())
+	_, err = client.CustomerGroups().WithId(d.Id()).Post(input).Execute(ctx)
 	if err != nil {
 		if ctErr, ok := err.(platform.ErrorResponse); ok {
 			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceCustomerGroupRead(d, m)
+	return resourceCustomerGroupRead(ctx, d, m)
 }
 
-func resourceCustomerGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCustomerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := getClient(m)
 	version := d.Get("version").(int)
 	_, err := client.CustomerGroups().WithId(d.Id()).Delete().WithQueryParams(platform.ByProjectKeyCustomerGroupsByIDRequestMethodDeleteInput{
 		Version: version,
-	}).Execute(context.Background())
+	}).Execute(ctx)
 	if err != nil {
-		log.Printf("[ERROR] Error during deleting customer group resource %s", err)
-		return nil
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -0,0 +1,234 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+func resourceCustomerGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the set of Customers that belong to a Customer Group as its own resource, " +
+			"separate from `commercetools_customer_group`. commercetools itself stores group membership on " +
+			"the Customer, not on the group, so this resource works by setting and clearing the " +
+			"`customerGroup` reference on each tracked Customer.\n\n" +
+			"When `exclusive` is `true` (the default) this resource owns the full membership of the group " +
+			"and will remove any Customer it finds assigned to the group that is not listed in `member_ids`. " +
+			"Set it to `false` to let several resources additively manage members of the same group, in which " +
+			"case this resource will only ever add or remove the Customers it was given.",
+		CreateContext: resourceCustomerGroupMembershipCreate,
+		ReadContext:   resourceCustomerGroupMembershipRead,
+		UpdateContext: resourceCustomerGroupMembershipUpdate,
+		DeleteContext: resourceCustomerGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"customer_group_id": {
+				Description: "ID of the customer group whose membership is managed",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"member_ids": {
+				Description: "IDs of the customers that should belong to the customer group",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"exclusive": {
+				Description: "Whether this resource manages all members of the group (`true`), removing any " +
+					"customer assigned to the group out of band, or only the members listed in `member_ids` " +
+					"(`false`), allowing multiple resources to co-manage the group's membership",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceCustomerGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(m)
+	groupID := d.Get("customer_group_id").(string)
+	memberIDs := expandStringSet(d.Get("member_ids").(*schema.Set))
+
+	for _, customerID := range memberIDs {
+		if err := setCustomerGroupForCustomer(ctx, client, customerID, &groupID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(groupID)
+
+	return resourceCustomerGroupMembershipRead(ctx, d, m)
+}
+
+func resourceCustomerGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Reading customer group membership for customer group: %s", d.Id())
+
+	client := getClient(m)
+
+	actualIDs, err := queryCustomerGroupMemberIDs(ctx, client, d.Id())
+	if err != nil {
+		if ctErr, ok := err.(platform.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if d.Get("exclusive").(bool) {
+		d.Set("member_ids", actualIDs)
+	} else {
+		// Only keep track of the customers this resource manages that are still
+		// actually a member of the group, so drift from removing one out-of-band
+		// (or another resource managing the same group) is reflected in state.
+		tracked := expandStringSet(d.Get("member_ids").(*schema.Set))
+		actual := make(map[string]bool, len(actualIDs))
+		for _, id := range actualIDs {
+			actual[id] = true
+		}
+
+		stillMember := make([]string, 0, len(tracked))
+		for _, id := range tracked {
+			if actual[id] {
+				stillMember = append(stillMember, id)
+			}
+		}
+		d.Set("member_ids", stillMember)
+	}
+
+	d.Set("customer_group_id", d.Id())
+
+	return nil
+}
+
+func resourceCustomerGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(m)
+	groupID := d.Get("customer_group_id").(string)
+
+	if d.HasChange("member_ids") {
+		old, new := d.GetChange("member_ids")
+		toAdd, toRemove := diffStringSets(old.(*schema.Set), new.(*schema.Set))
+
+		for _, customerID := range toAdd {
+			if err := setCustomerGroupForCustomer(ctx, client, customerID, &groupID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		for _, customerID := range toRemove {
+			if err := setCustomerGroupForCustomer(ctx, client, customerID, nil); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceCustomerGroupMembershipRead(ctx, d, m)
+}
+
+func resourceCustomerGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(m)
+	memberIDs := expandStringSet(d.Get("member_ids").(*schema.Set))
+
+	for _, customerID := range memberIDs {
+		if err := setCustomerGroupForCustomer(ctx, client, customerID, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// customerGroupMembershipPageSize is the page size used when listing the
+// customers of a group. The commercetools API defaults to a page size of 20,
+// far below what a real customer group can hold, so every list must page
+// through the full result set rather than trust the first page alone.
+const customerGroupMembershipPageSize = 500
+
+// queryCustomerGroupMemberIDs returns the IDs of every Customer currently
+// assigned to the given customer group, paging through the full result set.
+func queryCustomerGroupMemberIDs(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, groupID string) ([]string, error) {
+	where := []string{fmt.Sprintf(`customerGroup(id="%s")`, groupID)}
+	limit := customerGroupMembershipPageSize
+
+	var ids []string
+	for offset := 0; ; offset += limit {
+		result, err := client.Customers().Get().WithQueryParams(platform.ByProjectKeyCustomersGetMethodParams{
+			Where:  where,
+			Limit:  &limit,
+			Offset: &offset,
+		}).Execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, customer := range result.Results {
+			ids = append(ids, customer.ID)
+		}
+
+		if offset+len(result.Results) >= result.Total || len(result.Results) == 0 {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// setCustomerGroupForCustomer assigns (groupID != nil) or clears (groupID ==
+// nil) the customer group of a single customer, retrying on concurrent
+// modification as the rest of the provider does.
+func setCustomerGroupForCustomer(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, customerID string, groupID *string) error {
+	var customerGroupAction platform.CustomerUpdateAction
+	if groupID == nil {
+		customerGroupAction = &platform.CustomerSetCustomerGroupAction{CustomerGroup: nil}
+	} else {
+		customerGroupAction = &platform.CustomerSetCustomerGroupAction{
+			CustomerGroup: &platform.CustomerGroupResourceIdentifier{ID: groupID},
+		}
+	}
+
+	return resource.RetryContext(ctx, 1*time.Minute, func() *resource.RetryError {
+		customer, err := client.Customers().WithId(customerID).Get().Execute(ctx)
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+
+		_, err = client.Customers().WithId(customerID).Post(platform.CustomerUpdate{
+			Version: customer.Version,
+			Actions: []platform.CustomerUpdateAction{customerGroupAction},
+		}).Execute(ctx)
+
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+		return nil
+	})
+}
+
+func expandStringSet(set *schema.Set) []string {
+	result := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+	return result
+}
+
+func diffStringSets(old, new *schema.Set) (toAdd []string, toRemove []string) {
+	for _, v := range new.Difference(old).List() {
+		toAdd = append(toAdd, v.(string))
+	}
+	for _, v := range old.Difference(new).List() {
+		toRemove = append(toRemove, v.(string))
+	}
+	return
+}
@@ -0,0 +1,215 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+func TestAccCustomerGroupMembership_Exclusive(t *testing.T) {
+	groupName := "acctest-membership-exclusive"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckCustomerGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerGroupMembershipExclusiveConfig(groupName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member", "exclusive", "true"),
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member", "member_ids.#", "1"),
+					testAccCheckCustomerInGroup("commercetools_customer.customer_1", "commercetools_customer_group.group"),
+				),
+			},
+			{
+				Config: testAccCustomerGroupMembershipExclusiveConfig(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member", "member_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomerGroupMembership_Additive(t *testing.T) {
+	groupName := "acctest-membership-additive"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckCustomerGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerGroupMembershipAdditiveConfig(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member_1", "exclusive", "false"),
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member_2", "exclusive", "false"),
+					testAccCheckCustomerInGroup("commercetools_customer.customer_1", "commercetools_customer_group.group"),
+					testAccCheckCustomerInGroup("commercetools_customer.customer_2", "commercetools_customer_group.group"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomerGroupMembership_DriftOutOfBand(t *testing.T) {
+	groupName := "acctest-membership-drift"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckCustomerGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerGroupMembershipExclusiveConfig(groupName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("commercetools_customer_group_membership.member", "member_ids.#", "1"),
+					testAccClearCustomerGroup("commercetools_customer.customer_1"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				RefreshState: true,
+				Check: resource.TestCheckResourceAttr(
+					"commercetools_customer_group_membership.member", "member_ids.#", "0"),
+			},
+		},
+	})
+}
+
+func testAccCustomerGroupMembershipExclusiveConfig(groupName string, includeMember bool) string {
+	memberIDs := `[]`
+	if includeMember {
+		memberIDs = `[commercetools_customer.customer_1.id]`
+	}
+
+	return fmt.Sprintf(`
+resource "commercetools_customer_group" "group" {
+	name = "%s"
+}
+
+resource "commercetools_customer" "customer_1" {
+	email      = "membership-exclusive-1@example.com"
+	first_name = "Exclusive"
+	last_name  = "One"
+}
+
+resource "commercetools_customer_group_membership" "member" {
+	customer_group_id = commercetools_customer_group.group.id
+	member_ids        = %s
+	exclusive         = true
+}
+`, groupName, memberIDs)
+}
+
+func testAccCustomerGroupMembershipAdditiveConfig(groupName string) string {
+	return fmt.Sprintf(`
+resource "commercetools_customer_group" "group" {
+	name = "%s"
+}
+
+resource "commercetools_customer" "customer_1" {
+	email      = "membership-additive-1@example.com"
+	first_name = "Additive"
+	last_name  = "One"
+}
+
+resource "commercetools_customer" "customer_2" {
+	email      = "membership-additive-2@example.com"
+	first_name = "Additive"
+	last_name  = "Two"
+}
+
+resource "commercetools_customer_group_membership" "member_1" {
+	customer_group_id = commercetools_customer_group.group.id
+	member_ids        = [commercetools_customer.customer_1.id]
+	exclusive         = false
+}
+
+resource "commercetools_customer_group_membership" "member_2" {
+	customer_group_id = commercetools_customer_group.group.id
+	member_ids        = [commercetools_customer.customer_2.id]
+	exclusive         = false
+}
+`, groupName)
+}
+
+func testAccCheckCustomerInGroup(customerResourceName string, groupResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		customerRs, ok := s.RootModule().Resources[customerResourceName]
+		if !ok {
+			return fmt.Errorf("customer resource not found: %s", customerResourceName)
+		}
+		groupRs, ok := s.RootModule().Resources[groupResourceName]
+		if !ok {
+			return fmt.Errorf("customer group resource not found: %s", groupResourceName)
+		}
+
+		client := getClient(testAccProvider.Meta())
+		customer, err := client.Customers().WithId(customerRs.Primary.ID).Get().Execute(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if customer.CustomerGroup == nil || customer.CustomerGroup.ID != groupRs.Primary.ID {
+			return fmt.Errorf("expected customer %s to belong to customer group %s", customerRs.Primary.ID, groupRs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+// testAccClearCustomerGroup simulates a customer being removed from their
+// customer group out-of-band (i.e. not through this resource), to exercise
+// the drift detection in resourceCustomerGroupMembershipRead.
+func testAccClearCustomerGroup(customerResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[customerResourceName]
+		if !ok {
+			return fmt.Errorf("customer resource not found: %s", customerResourceName)
+		}
+
+		client := getClient(testAccProvider.Meta())
+		customer, err := client.Customers().WithId(rs.Primary.ID).Get().Execute(context.Background())
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Customers().WithId(rs.Primary.ID).Post(platform.CustomerUpdate{
+			Version: customer.Version,
+			Actions: []platform.CustomerUpdateAction{
+				&platform.CustomerSetCustomerGroupAction{CustomerGroup: nil},
+			},
+		}).Execute(context.Background())
+		return err
+	}
+}
+
+func testAccCheckCustomerGroupMembershipDestroy(s *terraform.State) error {
+	client := getClient(testAccProvider.Meta())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "commercetools_customer_group_membership" {
+			continue
+		}
+
+		ids, err := queryCustomerGroupMemberIDs(context.Background(), client, rs.Primary.ID)
+		if err != nil {
+			if ctErr, ok := err.(platform.ErrorResponse); ok && ctErr.StatusCode == 404 {
+				continue
+			}
+			return err
+		}
+
+		if len(ids) > 0 {
+			return fmt.Errorf("customer group %s still has members after destroy", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}